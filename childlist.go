@@ -0,0 +1,119 @@
+package radix
+
+import "sort"
+
+// childList is the storage strategy for a Node's children. Two children of
+// the same node are guaranteed to differ on the first byte of their Label
+// (that's what the split logic in Insert enforces), so that byte is always
+// enough to dispatch a lookup to the right child.
+type childList interface {
+	find(b byte) *Node
+	add(n *Node)
+	remove(b byte)
+	sorted() []*Node
+	len() int
+}
+
+// sparseChildList is a small, sorted slice of children searched by binary
+// search on the first byte of each Label. It's cheap for the common case of
+// low-fanout nodes, which is most of a radix tree away from the root.
+type sparseChildList struct {
+	children []*Node
+}
+
+func newSparseChildList() *sparseChildList {
+	return &sparseChildList{}
+}
+
+func (l *sparseChildList) find(b byte) *Node {
+	i := l.search(b)
+	if i < len(l.children) && l.children[i].Label[0] == b {
+		return l.children[i]
+	}
+	return nil
+}
+
+func (l *sparseChildList) add(n *Node) {
+	i := l.search(n.Label[0])
+	l.children = append(l.children, nil)
+	copy(l.children[i+1:], l.children[i:])
+	l.children[i] = n
+}
+
+func (l *sparseChildList) remove(b byte) {
+	i := l.search(b)
+	if i >= len(l.children) || l.children[i].Label[0] != b {
+		return
+	}
+	l.children = append(l.children[:i], l.children[i+1:]...)
+}
+
+func (l *sparseChildList) sorted() []*Node {
+	return l.children
+}
+
+func (l *sparseChildList) len() int {
+	return len(l.children)
+}
+
+func (l *sparseChildList) search(b byte) int {
+	return sort.Search(len(l.children), func(i int) bool {
+		return l.children[i].Label[0] >= b
+	})
+}
+
+// denseChildList is a map-backed representation used once a node's fanout
+// exceeds MaxChildrenPerSparseNode, trading a little memory for O(1) lookup
+// instead of sparseChildList's O(log n) binary search. sorted() caches its
+// result, recomputed eagerly by add/remove rather than lazily by sorted()
+// itself, so that sorted() stays a pure read: with a Concurrent tree several
+// readers may call it at once under nothing stronger than the shared read
+// lock, and a lazy cache write on that path would race.
+type denseChildList struct {
+	children map[byte]*Node
+	cache    []*Node
+}
+
+func newDenseChildList(sparse *sparseChildList) *denseChildList {
+	l := &denseChildList{children: make(map[byte]*Node, len(sparse.children))}
+	for _, child := range sparse.children {
+		l.children[child.Label[0]] = child
+	}
+	l.rebuildCache()
+	return l
+}
+
+func (l *denseChildList) find(b byte) *Node {
+	return l.children[b]
+}
+
+func (l *denseChildList) add(n *Node) {
+	l.children[n.Label[0]] = n
+	l.rebuildCache()
+}
+
+func (l *denseChildList) remove(b byte) {
+	delete(l.children, b)
+	l.rebuildCache()
+}
+
+func (l *denseChildList) sorted() []*Node {
+	return l.cache
+}
+
+func (l *denseChildList) len() int {
+	return len(l.children)
+}
+
+func (l *denseChildList) rebuildCache() {
+	children := make([]*Node, 0, len(l.children))
+	for _, child := range l.children {
+		children = append(children, child)
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Label < children[j].Label
+	})
+
+	l.cache = children
+}
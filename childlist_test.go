@@ -0,0 +1,67 @@
+package radix
+
+import "testing"
+
+func TestTreePromotesToDenseChildList(t *testing.T) {
+	rt := InitTree(MaxChildrenPerSparseNode(3))
+
+	letters := []string{"a", "b", "c", "d", "e"}
+	for _, letter := range letters {
+		rt.InsertWord(letter)
+	}
+
+	if _, ok := rt.Root.children.(*denseChildList); !ok {
+		t.Errorf("Root should have been promoted to a denseChildList after exceeding the threshold")
+	}
+
+	for _, letter := range letters {
+		if !rt.Search(letter) {
+			t.Errorf("'%s' should be found after the node was promoted to dense storage", letter)
+		}
+	}
+
+	if len(rt.Root.Children()) != len(letters) {
+		t.Errorf("Expected %d children, found %d", len(letters), len(rt.Root.Children()))
+	}
+}
+
+func TestTreeStaysSparseUnderThreshold(t *testing.T) {
+	rt := InitTree()
+
+	letters := []string{"a", "b", "c"}
+	for _, letter := range letters {
+		rt.InsertWord(letter)
+	}
+
+	if _, ok := rt.Root.children.(*sparseChildList); !ok {
+		t.Errorf("Root should still be a sparseChildList below the threshold")
+	}
+}
+
+func TestDenseChildListOperations(t *testing.T) {
+	sparse := newSparseChildList()
+	for _, label := range []string{"a", "b", "c"} {
+		sparse.add(InitNode(label))
+	}
+
+	dense := newDenseChildList(sparse)
+
+	if dense.len() != 3 {
+		t.Errorf("Expected dense list to have 3 children, found %d", dense.len())
+	}
+
+	if dense.find('b') == nil {
+		t.Errorf("Expected to find child 'b' in the dense list")
+	}
+
+	dense.remove('b')
+
+	if dense.find('b') != nil {
+		t.Errorf("'b' should be gone after remove")
+	}
+
+	sorted := dense.sorted()
+	if len(sorted) != 2 || sorted[0].Label != "a" || sorted[1].Label != "c" {
+		t.Errorf("Expected sorted children [a c], found %v", sorted)
+	}
+}
@@ -0,0 +1,59 @@
+package radix
+
+import "sync"
+
+// Concurrent makes a Tree safe for concurrent use by multiple goroutines. It
+// guards the tree with a sync.RWMutex: Insert and Delete take the write
+// lock; Get, Search, LongestPrefix, Minimum, Maximum, GetSuggestions,
+// GetSuggestionsForSlice, and Walk/WalkPrefix/WalkPath take the read lock.
+// A Tree created without this option performs no locking at all.
+func Concurrent(enabled bool) Option {
+	return func(t *Tree) {
+		if enabled {
+			t.mu = &sync.RWMutex{}
+		} else {
+			t.mu = nil
+		}
+	}
+}
+
+// AllowReentrantRead stops Walk, WalkPrefix, and WalkPath from holding the
+// read lock for the duration of the traversal.
+//
+// Without it, a Walk callback must never call back into the tree (Get,
+// Search, Insert, Delete, another Walk, ...): Go's sync.RWMutex only
+// permits a goroutine to hold nested read locks so long as no writer is
+// waiting in between the two RLock calls, and a callback-triggered write
+// attempt queued mid-walk will deadlock the traversal waiting on its own
+// lock. Enable this only when callbacks need to read the tree and the
+// caller can otherwise guarantee no writer runs for the duration of the
+// walk (for example, a read-only snapshot of the tree's lifecycle).
+func AllowReentrantRead(enabled bool) Option {
+	return func(t *Tree) {
+		t.allowReentrantRead = enabled
+	}
+}
+
+func (t *Tree) rlock() {
+	if t.mu != nil {
+		t.mu.RLock()
+	}
+}
+
+func (t *Tree) runlock() {
+	if t.mu != nil {
+		t.mu.RUnlock()
+	}
+}
+
+func (t *Tree) lock() {
+	if t.mu != nil {
+		t.mu.Lock()
+	}
+}
+
+func (t *Tree) unlock() {
+	if t.mu != nil {
+		t.mu.Unlock()
+	}
+}
@@ -0,0 +1,94 @@
+package radix
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// concurrencyTestWords generates a deterministic word list without relying
+// on a system dictionary being present.
+func concurrencyTestWords(n int) []string {
+	words := make([]string, n)
+	for i := 0; i < n; i++ {
+		words[i] = fmt.Sprintf("word%d", i)
+	}
+	return words
+}
+
+// TestTreeConcurrentReadersAndWriters hammers a Concurrent(true) tree with
+// many readers alongside a handful of writers. Run with -race to confirm
+// the RWMutex actually guards every access path.
+func TestTreeConcurrentReadersAndWriters(t *testing.T) {
+	rt := InitTree(Concurrent(true))
+
+	words := concurrencyTestWords(200)
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for _, word := range words {
+				rt.Search(word)
+				rt.Get(word)
+				rt.LongestPrefix(word)
+				rt.Minimum()
+				rt.Maximum()
+				rt.GetSuggestions(word[:4])
+				rt.GetSuggestionsForSlice([]string{word[:4], "word1"})
+				_ = rt.Walk(func(key string, value interface{}) error { return nil })
+			}
+		}(i)
+	}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				key := fmt.Sprintf("writer%d-%d", worker, j)
+				rt.Insert(key, j)
+				rt.Delete(key)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// TestTreeAllowReentrantRead confirms a Walk callback can call back into the
+// tree (Get/Search) without deadlocking when AllowReentrantRead(true) is set,
+// and that it still sees a consistent, fully-built tree while doing so.
+func TestTreeAllowReentrantRead(t *testing.T) {
+	rt := InitTree(Concurrent(true), AllowReentrantRead(true))
+
+	words := []string{"test", "team", "toast"}
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	var reentrantHits int
+
+	err := rt.Walk(func(key string, value interface{}) error {
+		if !rt.Search(key) {
+			t.Errorf("reentrant Search('%s') from within Walk should find the key", key)
+		}
+		if _, ok := rt.Get(key); !ok {
+			t.Errorf("reentrant Get('%s') from within Walk should find the key", key)
+		}
+		reentrantHits++
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Walk returned an unexpected error: %v", err)
+	}
+	if reentrantHits != len(words) {
+		t.Errorf("Expected %d reentrant callback invocations, found %d", len(words), reentrantHits)
+	}
+}
@@ -11,13 +11,16 @@ type Dictionary interface {
 	GetWords() ([]string, error)
 }
 
-// LinuxDictionary defines the words file in a Linux file system
-type LinuxDictionary struct{}
+// FileDictionary defines a words file at an arbitrary path on the local file
+// system, one word per line.
+type FileDictionary struct {
+	Path string
+}
 
-// GetWords for the linux dictionary returns a slice of all of the words stored
-// in the Linux dictionary file
-func (d *LinuxDictionary) GetWords() ([]string, error) {
-	f, err := os.Open("/usr/share/dict/words")
+// GetWords for a FileDictionary returns a slice of all of the words stored
+// in the file at d.Path.
+func (d *FileDictionary) GetWords() ([]string, error) {
+	f, err := os.Open(d.Path)
 	defer f.Close()
 
 	if err != nil {
@@ -39,3 +42,12 @@ func (d *LinuxDictionary) GetWords() ([]string, error) {
 
 	return words, nil
 }
+
+// LinuxDictionary defines the words file in a Linux file system
+type LinuxDictionary struct{}
+
+// GetWords for the linux dictionary returns a slice of all of the words stored
+// in the Linux dictionary file
+func (d *LinuxDictionary) GetWords() ([]string, error) {
+	return (&FileDictionary{Path: "/usr/share/dict/words"}).GetWords()
+}
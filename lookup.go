@@ -0,0 +1,112 @@
+package radix
+
+import "strings"
+
+// LongestPrefix returns the longest inserted key that is a prefix of s,
+// along with its value. This is the workhorse behind routing tables and
+// URL/path dispatch, where the most specific registered route should win.
+// ok is false if no inserted key is a prefix of s.
+func (t *Tree) LongestPrefix(s string) (string, interface{}, bool) {
+	t.rlock()
+	defer t.runlock()
+
+	var key string
+	var value interface{}
+	var ok bool
+
+	node := t.Root
+	matchedKey := ""
+	remaining := s
+
+	for {
+		if node.IsWord() {
+			key, value, ok = matchedKey, node.Value, true
+		}
+
+		if remaining == "" {
+			return key, value, ok
+		}
+
+		child := childWithPrefix(node, remaining)
+		if child == nil {
+			return key, value, ok
+		}
+
+		matchedKey += child.Label
+		remaining = remaining[len(child.Label):]
+		node = child
+	}
+}
+
+// Minimum returns the lexicographically smallest inserted key and its
+// value. ok is false if the tree is empty.
+func (t *Tree) Minimum() (string, interface{}, bool) {
+	t.rlock()
+	defer t.runlock()
+
+	node := t.Root
+	key := ""
+
+	for {
+		if node.IsWord() {
+			return key, node.Value, true
+		}
+
+		children := node.children.sorted()
+		if len(children) == 0 {
+			return "", nil, false
+		}
+
+		node = children[0]
+		key += node.Label
+	}
+}
+
+// Maximum returns the lexicographically largest inserted key and its
+// value. ok is false if the tree is empty.
+func (t *Tree) Maximum() (string, interface{}, bool) {
+	t.rlock()
+	defer t.runlock()
+
+	type step struct {
+		node *Node
+		key  string
+	}
+
+	path := []step{{t.Root, ""}}
+	node, key := t.Root, ""
+
+	for !node.IsLeaf() {
+		children := node.children.sorted()
+		node = children[len(children)-1]
+		key += node.Label
+		path = append(path, step{node, key})
+	}
+
+	// A correctly compacted tree never has a non-boundary leaf, so the
+	// deepest node found above is always the answer. We still climb back
+	// up looking for the nearest boundary node as a defensive fallback.
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].node.IsWord() {
+			return path[i].key, path[i].node.Value, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// childWithPrefix returns node's child whose label is a prefix of query, or
+// nil if none of node's children match. Since sibling labels are guaranteed
+// to differ on their first byte, at most one child can ever match.
+func childWithPrefix(node *Node, query string) *Node {
+	if query == "" {
+		return nil
+	}
+
+	child := node.children.find(query[0])
+	if child != nil && strings.HasPrefix(query, child.Label) {
+		return child
+	}
+
+	return nil
+}
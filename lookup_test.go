@@ -0,0 +1,82 @@
+package radix
+
+import "testing"
+
+func TestTreeLongestPrefix(t *testing.T) {
+	rt := InitTree()
+	rt.Insert("te", "te-value")
+	rt.Insert("test", "test-value")
+	rt.Insert("tester", "tester-value")
+
+	cases := map[string]string{
+		"t":        "",
+		"te":       "te",
+		"tes":      "te",
+		"test":     "test",
+		"testers":  "tester",
+		"tester":   "tester",
+		"testerss": "tester",
+	}
+
+	for query, expectedKey := range cases {
+		key, _, ok := rt.LongestPrefix(query)
+
+		if expectedKey == "" {
+			if ok {
+				t.Errorf("Expected no match for '%s', found key '%s'", query, key)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Errorf("Expected a match for '%s', found none", query)
+			continue
+		}
+
+		if key != expectedKey {
+			t.Errorf("Expected LongestPrefix('%s') to return '%s', found '%s'", query, expectedKey, key)
+		}
+	}
+
+	if _, value, _ := rt.LongestPrefix("testers"); value != "tester-value" {
+		t.Errorf("Expected value 'tester-value' for LongestPrefix('testers'), found %v", value)
+	}
+}
+
+func TestTreeLongestPrefixEmptyTree(t *testing.T) {
+	rt := InitTree()
+
+	if _, _, ok := rt.LongestPrefix("test"); ok {
+		t.Errorf("Expected no match against an empty tree")
+	}
+}
+
+func TestTreeMinimumAndMaximum(t *testing.T) {
+	rt := InitTree()
+	words := []string{"team", "test", "toast", "slow"}
+
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	minKey, _, ok := rt.Minimum()
+	if !ok || minKey != "slow" {
+		t.Errorf("Expected Minimum() to be 'slow', found '%s' (ok=%v)", minKey, ok)
+	}
+
+	maxKey, _, ok := rt.Maximum()
+	if !ok || maxKey != "toast" {
+		t.Errorf("Expected Maximum() to be 'toast', found '%s' (ok=%v)", maxKey, ok)
+	}
+}
+
+func TestTreeMinimumAndMaximumEmptyTree(t *testing.T) {
+	rt := InitTree()
+
+	if _, _, ok := rt.Minimum(); ok {
+		t.Errorf("Expected Minimum() to report ok=false for an empty tree")
+	}
+	if _, _, ok := rt.Maximum(); ok {
+		t.Errorf("Expected Maximum() to report ok=false for an empty tree")
+	}
+}
@@ -4,25 +4,29 @@ package radix
 // node with the difference that Label can be an arbitrary length depending on
 // what other words are added to the tree. Prefix is stored so we don't need to
 // determine a given node's prefix at query time when gathering suggestions for
-// a query.
+// a query. Value holds whatever data a caller has associated with the key
+// ending at this node and is only meaningful when IsWordBoundary is true.
+// Children are held behind the unexported childList interface rather than a
+// plain slice so a node's storage can switch from sparse to dense as its
+// fanout grows; use Children to read them back out.
 type Node struct {
 	Label          string
 	Prefix         string
-	Children       []*Node
+	Value          interface{}
 	IsWordBoundary bool
 	IsRootNode     bool
+	children       childList
 }
 
 // InitNode initializes an empty Node
 func InitNode(label string) *Node {
-	var children []*Node
-
 	return &Node{
 		Label:          label,
 		Prefix:         "",
-		Children:       children,
+		Value:          nil,
 		IsWordBoundary: false,
 		IsRootNode:     false,
+		children:       newSparseChildList(),
 	}
 }
 
@@ -38,5 +42,10 @@ func (n *Node) IsRoot() bool {
 
 // IsLeaf checks if a node has any children
 func (n *Node) IsLeaf() bool {
-	return len(n.Children) == 0
+	return n.children.len() == 0
+}
+
+// Children returns a node's children sorted by label.
+func (n *Node) Children() []*Node {
+	return n.children.sorted()
 }
@@ -0,0 +1,295 @@
+package radix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// snapshotMagic identifies a radix tree snapshot stream.
+var snapshotMagic = [4]byte{'R', 'A', 'D', 'X'}
+
+// snapshotVersion is bumped whenever the on-disk format below changes in an
+// incompatible way.
+const snapshotVersion = 1
+
+// Flags stored alongside each serialized node.
+const (
+	flagWordBoundary byte = 1 << iota
+	flagHasValue
+	flagIsRoot
+)
+
+// ErrInvalidSnapshot is returned by LoadTree when r does not contain a
+// recognizable snapshot: a bad magic, an unsupported version, or a stream
+// that ends before the format says it should.
+var ErrInvalidSnapshot = errors.New("radix: invalid snapshot")
+
+// WriteTo serializes the tree to w in a compact, versioned binary format: a
+// 4-byte magic ("RADX"), a 1-byte version, followed by a pre-order walk of
+// the nodes. Each node is written as uvarint(labelLen) | label | a 1-byte
+// flags field (IsWordBoundary, HasValue, IsRoot) | an optional
+// length-prefixed gob-encoded value | uvarint(childCount), followed
+// recursively by that many children. Node.Prefix is not stored; LoadTree
+// recomputes it from ancestor labels.
+func (t *Tree) WriteTo(w io.Writer) (int64, error) {
+	t.rlock()
+	defer t.runlock()
+
+	cw := &countingWriter{w: w}
+
+	if _, err := cw.Write(snapshotMagic[:]); err != nil {
+		return cw.n, err
+	}
+	if _, err := cw.Write([]byte{snapshotVersion}); err != nil {
+		return cw.n, err
+	}
+	if err := writeNode(cw, t.Root); err != nil {
+		return cw.n, err
+	}
+
+	return cw.n, nil
+}
+
+// LoadTree reconstructs a Tree previously written with Tree.WriteTo.
+func LoadTree(r io.Reader) (*Tree, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, ErrInvalidSnapshot
+	}
+	if !bytes.Equal(header[:4], snapshotMagic[:]) {
+		return nil, ErrInvalidSnapshot
+	}
+	if header[4] != snapshotVersion {
+		return nil, ErrInvalidSnapshot
+	}
+
+	tree := InitTree()
+
+	root, err := readNode(r, tree, "")
+	if err != nil {
+		return nil, err
+	}
+	root.IsRootNode = true
+	tree.Root = root
+
+	return tree, nil
+}
+
+func writeNode(w *countingWriter, node *Node) error {
+	if err := writeUvarint(w, uint64(len(node.Label))); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(node.Label)); err != nil {
+		return err
+	}
+
+	var flags byte
+	if node.IsWordBoundary {
+		flags |= flagWordBoundary
+	}
+	hasValue := node.IsWordBoundary && node.Value != nil
+	if hasValue {
+		flags |= flagHasValue
+	}
+	if node.IsRootNode {
+		flags |= flagIsRoot
+	}
+	if _, err := w.Write([]byte{flags}); err != nil {
+		return err
+	}
+
+	if hasValue {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(&node.Value); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, uint64(buf.Len())); err != nil {
+			return err
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	children := node.children.sorted()
+	if err := writeUvarint(w, uint64(len(children))); err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := writeNode(w, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readNode(r io.Reader, tree *Tree, prefix string) (*Node, error) {
+	labelLen, err := binary.ReadUvarint(byteReader{r})
+	if err != nil {
+		return nil, ErrInvalidSnapshot
+	}
+
+	label := make([]byte, labelLen)
+	if _, err := io.ReadFull(r, label); err != nil {
+		return nil, ErrInvalidSnapshot
+	}
+
+	var flags [1]byte
+	if _, err := io.ReadFull(r, flags[:]); err != nil {
+		return nil, ErrInvalidSnapshot
+	}
+
+	node := InitNode(string(label))
+	node.Prefix = prefix
+	node.IsWordBoundary = flags[0]&flagWordBoundary != 0
+
+	if flags[0]&flagHasValue != 0 {
+		valueLen, err := binary.ReadUvarint(byteReader{r})
+		if err != nil {
+			return nil, ErrInvalidSnapshot
+		}
+
+		buf := make([]byte, valueLen)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, ErrInvalidSnapshot
+		}
+		if err := gob.NewDecoder(bytes.NewReader(buf)).Decode(&node.Value); err != nil {
+			return nil, ErrInvalidSnapshot
+		}
+	}
+
+	childCount, err := binary.ReadUvarint(byteReader{r})
+	if err != nil {
+		return nil, ErrInvalidSnapshot
+	}
+
+	childPrefix := prefix + node.Label
+	for i := uint64(0); i < childCount; i++ {
+		child, err := readNode(r, tree, childPrefix)
+		if err != nil {
+			return nil, err
+		}
+		tree.addChild(node, child)
+	}
+
+	return node, nil
+}
+
+// sourceModTimer is implemented by a Dictionary that is backed by a file on
+// disk, letting InitTreeFromDictCached tell whether a snapshot is stale
+// without having to re-read the dictionary itself.
+type sourceModTimer interface {
+	SourceModTime() (time.Time, error)
+}
+
+// SourceModTime reports the modification time of the file backing d, so
+// InitTreeFromDictCached can tell whether a snapshot taken from it is stale.
+func (d *FileDictionary) SourceModTime() (time.Time, error) {
+	info, err := os.Stat(d.Path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// InitTreeFromDictCached loads a Tree from the snapshot at snapshotPath if it
+// exists and is at least as new as dict (when dict implements
+// sourceModTimer; a dict with no file backing is always trusted as-is).
+// Otherwise it rebuilds the tree from dict via InitTreeFromDict and writes a
+// fresh snapshot to snapshotPath for next time.
+func InitTreeFromDictCached(dict Dictionary, snapshotPath string, opts ...Option) *Tree {
+	if tree := loadCachedSnapshot(dict, snapshotPath); tree != nil {
+		for _, opt := range opts {
+			opt(tree)
+		}
+		return tree
+	}
+
+	tree := InitTreeFromDict(dict, opts...)
+
+	f, err := os.Create(snapshotPath)
+	if err != nil {
+		log.Printf("radix: could not write snapshot to %s: %v", snapshotPath, err)
+		return tree
+	}
+	defer f.Close()
+
+	if _, err := tree.WriteTo(f); err != nil {
+		log.Printf("radix: could not write snapshot to %s: %v", snapshotPath, err)
+	}
+
+	return tree
+}
+
+// loadCachedSnapshot returns a Tree loaded from snapshotPath, or nil if the
+// snapshot is missing, stale, or corrupt and dict should be used instead.
+func loadCachedSnapshot(dict Dictionary, snapshotPath string) *Tree {
+	snapshotInfo, err := os.Stat(snapshotPath)
+	if err != nil {
+		return nil
+	}
+
+	if modTimer, ok := dict.(sourceModTimer); ok {
+		sourceModTime, err := modTimer.SourceModTime()
+		if err == nil && sourceModTime.After(snapshotInfo.ModTime()) {
+			return nil
+		}
+	}
+
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	tree, err := LoadTree(f)
+	if err != nil {
+		return nil
+	}
+
+	return tree
+}
+
+// writeUvarint writes x to w as an unsigned LEB128 varint.
+func writeUvarint(w io.Writer, x uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+// countingWriter wraps an io.Writer, tracking the total number of bytes
+// written through it so WriteTo can report it per the io.WriterTo contract.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// byteReader adapts an io.Reader to io.ByteReader one byte at a time, which
+// is all binary.ReadUvarint needs. Snapshot reads are dominated by label and
+// value bytes read through io.ReadFull, so the extra syscalls this costs for
+// the handful of varints per node are not worth buffering for.
+type byteReader struct {
+	r io.Reader
+}
+
+func (b byteReader) ReadByte() (byte, error) {
+	var buf [1]byte
+	if _, err := io.ReadFull(b.r, buf[:]); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
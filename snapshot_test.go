@@ -0,0 +1,110 @@
+package radix
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	rt := InitTree()
+	rt.Insert("test", 1)
+	rt.Insert("team", 2)
+	rt.Insert("toast", nil)
+	rt.Insert("water", "splash")
+
+	var buf bytes.Buffer
+	if _, err := rt.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+
+	loaded, err := LoadTree(&buf)
+	if err != nil {
+		t.Fatalf("LoadTree failed: %v", err)
+	}
+
+	for _, word := range []string{"test", "team", "toast", "water"} {
+		if !loaded.Search(word) {
+			t.Errorf("'%s' should be found after round-tripping through a snapshot", word)
+		}
+	}
+
+	for word, want := range map[string]interface{}{"test": 1, "team": 2, "toast": nil, "water": "splash"} {
+		got, ok := loaded.Get(word)
+		if !ok {
+			t.Errorf("Get('%s') should succeed after loading the snapshot", word)
+			continue
+		}
+		if got != want {
+			t.Errorf("Get('%s') = %v, want %v", word, got, want)
+		}
+	}
+
+	for _, word := range []string{"tea", "toasted", "wafer"} {
+		if loaded.Search(word) {
+			t.Errorf("'%s' should not be found, it was never inserted", word)
+		}
+	}
+}
+
+func TestSnapshotCorruptFallsBackToRebuild(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "snapshot.radx")
+
+	if err := os.WriteFile(snapshotPath, []byte("not a real snapshot"), 0o644); err != nil {
+		t.Fatalf("could not write fixture: %v", err)
+	}
+
+	wordsPath := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(wordsPath, []byte("test\nteam\ntoast\n"), 0o644); err != nil {
+		t.Fatalf("could not write fixture: %v", err)
+	}
+
+	dict := &FileDictionary{Path: wordsPath}
+
+	tree := InitTreeFromDictCached(dict, snapshotPath)
+
+	for _, word := range []string{"test", "team", "toast"} {
+		if !tree.Search(word) {
+			t.Errorf("'%s' should be found after falling back to rebuilding from %s", word, wordsPath)
+		}
+	}
+}
+
+func TestInitTreeFromDictCachedUsesFreshSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "snapshot.radx")
+	wordsPath := filepath.Join(dir, "words.txt")
+
+	if err := os.WriteFile(wordsPath, []byte("test\nteam\n"), 0o644); err != nil {
+		t.Fatalf("could not write fixture: %v", err)
+	}
+
+	dict := &FileDictionary{Path: wordsPath}
+
+	first := InitTreeFromDictCached(dict, snapshotPath)
+	if !first.Search("test") {
+		t.Fatalf("expected 'test' to be found after the initial build")
+	}
+
+	if _, err := os.Stat(snapshotPath); err != nil {
+		t.Fatalf("expected a snapshot to be written to %s: %v", snapshotPath, err)
+	}
+
+	// Appending a word to the dictionary after the snapshot was written
+	// should not appear in the cached tree: the snapshot is still fresh.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(snapshotPath, future, future); err != nil {
+		t.Fatalf("could not bump snapshot mtime: %v", err)
+	}
+	if err := os.WriteFile(wordsPath, []byte("test\nteam\ntoast\n"), 0o644); err != nil {
+		t.Fatalf("could not update fixture: %v", err)
+	}
+
+	second := InitTreeFromDictCached(dict, snapshotPath)
+	if second.Search("toast") {
+		t.Errorf("'toast' should not be found: the snapshot predating it should have been reused")
+	}
+}
@@ -5,34 +5,63 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"sync"
 )
 
+// defaultMaxChildrenPerSparseNode is how many children a node holds in its
+// sparse representation before addChild promotes it to a dense one, unless
+// overridden via MaxChildrenPerSparseNode.
+const defaultMaxChildrenPerSparseNode = 8
+
 // Tree represents the actual Radix Tree implementation. It is a group of
 // connected nodes which has been compacted unlike a Trie such that the number
 // of nodes is decreased to increase memory efficiency.
 type Tree struct {
 	Root *Node
+
+	maxChildrenPerSparseNode int
+	mu                       *sync.RWMutex
+	allowReentrantRead       bool
+}
+
+// Option configures a Tree at construction time via InitTree.
+type Option func(*Tree)
+
+// MaxChildrenPerSparseNode sets how many children a node may hold in its
+// sparse, binary-searched representation before it is promoted to a
+// dense, map-backed one. The default is 8.
+func MaxChildrenPerSparseNode(n int) Option {
+	return func(t *Tree) {
+		t.maxChildrenPerSparseNode = n
+	}
 }
 
 // InitTree creates a new Tree instance ready for insertion
-func InitTree() *Tree {
+func InitTree(opts ...Option) *Tree {
 	node := InitNode("*")
 	node.IsRootNode = true
 
-	return &Tree{
-		Root: node,
+	tree := &Tree{
+		Root:                     node,
+		maxChildrenPerSparseNode: defaultMaxChildrenPerSparseNode,
+	}
+
+	for _, opt := range opts {
+		opt(tree)
 	}
+
+	return tree
 }
 
 // InitTreeFromDict creates a new Tree instance loaded with the passed Dictionary
-func InitTreeFromDict(dict Dictionary) *Tree {
+func InitTreeFromDict(dict Dictionary, opts ...Option) *Tree {
 	words, err := dict.GetWords()
 
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	tree := InitTree()
+	tree := InitTree(opts...)
 
 	for _, word := range words {
 		tree.InsertWord(word)
@@ -41,6 +70,25 @@ func InitTreeFromDict(dict Dictionary) *Tree {
 	return tree
 }
 
+// addChild adds child to node's child list, promoting node from its sparse
+// representation to a dense one once it exceeds maxChildrenPerSparseNode.
+func (t *Tree) addChild(node, child *Node) {
+	node.children.add(child)
+
+	if sparse, ok := node.children.(*sparseChildList); ok && sparse.len() > t.maxChildrenPerSparseNode {
+		node.children = newDenseChildList(sparse)
+	}
+}
+
+// setChildren replaces node's entire child list with children.
+func (t *Tree) setChildren(node *Node, children ...*Node) {
+	node.children = newSparseChildList()
+
+	for _, child := range children {
+		t.addChild(node, child)
+	}
+}
+
 // findMatchedNodeMeta searches the tree until it finds a matched child node.
 // It returns the metadata about that match including:
 //
@@ -52,62 +100,78 @@ func InitTreeFromDict(dict Dictionary) *Tree {
 // This function serves as the main recursion algorithm for insertion,
 // searching, and suggesting words.
 func (t *Tree) findMatchedNodeMeta(query, prefix string, currentNode *Node) (*Node, int, string, string) {
-	for _, childNode := range currentNode.Children {
-		var matchedIdx int
-		matchNotFound := true
-
-		// Setup a "runner" which determines where in the node label and query we stop matching.
-		for matchedIdx < len(query) && matchedIdx < len(childNode.Label) && query[matchedIdx] == childNode.Label[matchedIdx] {
-			matchedIdx++
-			matchNotFound = false
-		}
+	if query == "" {
+		return currentNode, -1, query, prefix
+	}
 
-		// Skip to next child node if we don't have a match on this branch
-		if matchNotFound {
-			continue
-		}
+	// A node's children are guaranteed to differ on the first byte of their
+	// Label, so that byte alone is enough to find the one candidate without
+	// scanning every sibling.
+	childNode := currentNode.children.find(query[0])
+	if childNode == nil {
+		return currentNode, -1, query, prefix
+	}
 
-		// If we matched the entirety of the label then we need to recurse
-		// into the childNode's children
-		if matchedIdx == len(childNode.Label) {
-			return t.findMatchedNodeMeta(query[matchedIdx:], prefix+query[:matchedIdx], childNode)
-		}
+	var matchedIdx int
 
-		return childNode, matchedIdx, query, prefix
+	// Setup a "runner" which determines where in the node label and query we stop matching.
+	for matchedIdx < len(query) && matchedIdx < len(childNode.Label) && query[matchedIdx] == childNode.Label[matchedIdx] {
+		matchedIdx++
 	}
 
-	return currentNode, -1, query, prefix
+	// If we matched the entirety of the label then we need to recurse
+	// into the childNode's children
+	if matchedIdx == len(childNode.Label) {
+		return t.findMatchedNodeMeta(query[matchedIdx:], prefix+query[:matchedIdx], childNode)
+	}
+
+	return childNode, matchedIdx, query, prefix
 }
 
-// InsertWord adds a new word to the Radix Trie.
-func (t *Tree) InsertWord(word string) {
-	matchedNode, matchedIdx, restWord, prefix := t.findMatchedNodeMeta(word, "", t.Root)
+// Insert adds key to the tree with an associated value. If the key was
+// already present, its previous value is returned alongside updated=true;
+// otherwise the zero value and false are returned.
+func (t *Tree) Insert(key string, value interface{}) (interface{}, bool) {
+	t.lock()
+	defer t.unlock()
+
+	matchedNode, matchedIdx, restWord, prefix := t.findMatchedNodeMeta(key, "", t.Root)
+
+	// We walked all the way down to a node that already represents key,
+	// whether that's a previously inserted word or a branch point whose
+	// label exactly matches key.
+	if matchedIdx == -1 && len(restWord) == 0 {
+		old := matchedNode.Value
+		updated := matchedNode.IsWordBoundary
+		matchedNode.IsWordBoundary = true
+		matchedNode.Value = value
+		return old, updated
+	}
 
 	// If we reached the end of the tree and couldn't find anything else to
-	// match then we simply add the rest of the word as a new child node to the
+	// match then we simply add the rest of the key as a new child node to the
 	// final matched node instance.
 	if matchedIdx == -1 {
-		// This word is already in the tree
-		if len(restWord) == 0 {
-			return
-		}
 		newNode := InitNode(restWord)
 		newNode.IsWordBoundary = true
+		newNode.Value = value
 		matchedNode.Prefix = prefix
-		matchedNode.Children = append(matchedNode.Children, newNode)
-		return
+		t.addChild(matchedNode, newNode)
+		return nil, false
 	}
 
 	// We have a partial prefix match but it will require that we split
 	// the current label into two children. We split the current label
-	// and word to insert and make new child nodes with the "rest" of
+	// and key to insert and make new child nodes with the "rest" of
 	// each of those strings.
 	cachedIsWord := matchedNode.IsWord()
 	cachedLabel := matchedNode.Label
+	cachedValue := matchedNode.Value
 
 	matchedNode.Label = restWord[:matchedIdx]
 	matchedNode.Prefix = restWord[:matchedIdx]
 	matchedNode.IsWordBoundary = false
+	matchedNode.Value = nil
 
 	// One important thing to remember is that we want to transfer the
 	// existing child nodes to the "rest" of the label so the previous
@@ -117,28 +181,136 @@ func (t *Tree) InsertWord(word string) {
 	restLabelNode := InitNode(cachedLabel[matchedIdx:])
 	restLabelNode.Prefix = cachedLabel
 	restLabelNode.IsWordBoundary = cachedIsWord
-	restLabelNode.Children = matchedNode.Children
+	restLabelNode.Value = cachedValue
+	restLabelNode.children = matchedNode.children
 
-	matchedNode.Children = []*Node{restLabelNode}
+	t.setChildren(matchedNode, restLabelNode)
 
-	// if the word we're inserting is a prefix to the current label then we
-	// don't need another branch
-	if restWord[matchedIdx:] != "" {
-		restWordNode := InitNode(restWord[matchedIdx:])
-		restWordNode.IsWordBoundary = true
-		matchedNode.Children = append(matchedNode.Children, restWordNode)
+	// if the key we're inserting is a prefix to the current label then it
+	// becomes the new word boundary instead of branching again
+	if restWord[matchedIdx:] == "" {
+		matchedNode.IsWordBoundary = true
+		matchedNode.Value = value
+		return nil, false
 	}
+
+	restWordNode := InitNode(restWord[matchedIdx:])
+	restWordNode.IsWordBoundary = true
+	restWordNode.Value = value
+	t.addChild(matchedNode, restWordNode)
+
+	return nil, false
+}
+
+// InsertWord adds a new word to the Radix Trie.
+func (t *Tree) InsertWord(word string) {
+	t.Insert(word, nil)
+}
+
+// Get looks up key in the tree and returns its associated value along with
+// whether the key was found.
+func (t *Tree) Get(key string) (interface{}, bool) {
+	t.rlock()
+	defer t.runlock()
+
+	return t.get(key)
+}
+
+func (t *Tree) get(key string) (interface{}, bool) {
+	matchedNode, _, restQuery, _ := t.findMatchedNodeMeta(key, "", t.Root)
+
+	if len(restQuery) != 0 || !matchedNode.IsWord() {
+		return nil, false
+	}
+
+	return matchedNode.Value, true
 }
 
 // Search looks in the tree to see if it can find a word based on a query
 func (t *Tree) Search(query string) bool {
-	matchedNode, _, restQuery, _ := t.findMatchedNodeMeta(query, "", t.Root)
-	return len(restQuery) == 0 && matchedNode.IsWord()
+	t.rlock()
+	defer t.runlock()
+
+	_, ok := t.get(query)
+	return ok
+}
+
+// Delete removes key from the tree, returning its associated value along
+// with whether the key was present. Deleting a key keeps the radix-
+// compaction invariant intact: a non-boundary node other than the root is
+// never left with exactly one child, and a non-boundary leaf is never left
+// dangling.
+func (t *Tree) Delete(key string) (interface{}, bool) {
+	t.lock()
+	defer t.unlock()
+
+	return t.delete(t.Root, key)
+}
+
+// delete walks down toward key, unmarking the boundary node once found, then
+// compacts the tree on the way back up the call stack: a node that becomes a
+// non-boundary node with a single child is merged into that child (the
+// inverse of the split performed in Insert), and a node left with a
+// non-boundary, childless node is unlinked entirely.
+func (t *Tree) delete(node *Node, key string) (interface{}, bool) {
+	if key == "" {
+		if !node.IsWordBoundary {
+			return nil, false
+		}
+
+		value := node.Value
+		node.IsWordBoundary = false
+		node.Value = nil
+
+		return value, true
+	}
+
+	child := node.children.find(key[0])
+	if child == nil || !strings.HasPrefix(key, child.Label) {
+		return nil, false
+	}
+
+	value, ok := t.delete(child, key[len(child.Label):])
+	if !ok {
+		return nil, false
+	}
+
+	switch {
+	case !child.IsWordBoundary && child.children.len() == 1:
+		mergeNodes(child, child.children.sorted()[0])
+	case !child.IsWordBoundary && child.children.len() == 0:
+		node.children.remove(child.Label[0])
+
+		if !node.IsRoot() && !node.IsWordBoundary && node.children.len() == 1 {
+			mergeNodes(node, node.children.sorted()[0])
+		}
+	}
+
+	return value, true
+}
+
+// mergeNodes folds child into node by concatenating their labels, the
+// inverse of the split performed in Insert. It is only ever called on a
+// non-boundary node with exactly one child, so node adopts child's identity
+// in every other respect.
+func mergeNodes(node, child *Node) {
+	node.Label += child.Label
+	node.Prefix = node.Label
+	node.IsWordBoundary = child.IsWordBoundary
+	node.Value = child.Value
+	node.children = child.children
 }
 
 // GetSuggestions returns any children that would complete the given search
 // query. This is useful for autocomplete.
 func (t *Tree) GetSuggestions(query string) []string {
+	t.rlock()
+	defer t.runlock()
+
+	return t.getSuggestions(query)
+}
+
+func (t *Tree) getSuggestions(query string) []string {
 	var suggestions []string
 
 	matchedNode, matchedIdx, restQuery, prefix := t.findMatchedNodeMeta(query, "", t.Root)
@@ -149,15 +321,13 @@ func (t *Tree) GetSuggestions(query string) []string {
 	}
 
 	// If we have a partial prefix match on the current node then we need to
-	// update the prefix for iteration. A leaf node will use the current prefix
-	// and it's label to finish the suggestion while a node with children has
-	// an updated prefix that we should use to iterate.
+	// update the prefix for iteration. prefix+matchedNode.Label is the full
+	// accumulated path to the node in both the leaf and non-leaf case:
+	// Node.Prefix is not the accumulated path from the root for every node
+	// (a split or a merge sets it to the node's own label), so it can't be
+	// substituted in here.
 	if matchedIdx > 0 && matchedIdx < len(matchedNode.Label) && matchedIdx == len(restQuery) && len(restQuery) > 0 {
-		if matchedNode.IsLeaf() {
-			prefix = prefix + matchedNode.Label
-		} else {
-			prefix = matchedNode.Prefix
-		}
+		prefix = prefix + matchedNode.Label
 	}
 
 	ch := make(chan string)
@@ -176,14 +346,20 @@ func (t *Tree) GetSuggestions(query string) []string {
 
 // GetSuggestionsForSlice does a concurrent pass on the tree gathering
 // suggestions for each search query and aggregating them into a unique slice.
+// The read lock, when the tree was created with Concurrent(true), is taken
+// once up front and held for every goroutine rather than being re-acquired
+// per query.
 func (t *Tree) GetSuggestionsForSlice(queries []string) []string {
+	t.rlock()
+	defer t.runlock()
+
 	queriesLeft := len(queries)
 
 	ch := make(chan []string, len(queries))
 
 	for _, query := range queries {
 		go func(ch chan []string, tree *Tree, query string) {
-			ch <- tree.GetSuggestions(query)
+			ch <- tree.getSuggestions(query)
 		}(ch, t, query)
 	}
 
@@ -219,11 +395,15 @@ func (t *Tree) GetSuggestionsForSlice(queries []string) []string {
 }
 
 // Iter creates a channel for consuming the words that have been added to the
-// tree allowing us to display them. It is driven by the helper recursive function.
+// tree allowing us to display them. It is driven by the helper recursive
+// function. The read lock, when held, is acquired before the channel is
+// handed back and released once the goroutine feeding it finishes.
 func (t *Tree) Iter() <-chan string {
+	t.rlock()
 	ch := make(chan string)
 
 	go func() {
+		defer t.runlock()
 		t.iter(ch, t.Root, "")
 		close(ch)
 	}()
@@ -236,7 +416,7 @@ func (t *Tree) iter(out chan<- string, node *Node, currentWord string) {
 		out <- currentWord
 	}
 
-	for _, child := range node.Children {
+	for _, child := range node.children.sorted() {
 		t.iter(out, child, currentWord+child.Label)
 	}
 }
@@ -252,7 +432,7 @@ func (t *Tree) debug(node *Node, depth int) {
 		fmt.Println(strings.Repeat("-", depth), node.Label, "|| PREFIX:", node.Prefix)
 	}
 
-	for _, child := range node.Children {
+	for _, child := range node.children.sorted() {
 		t.debug(child, depth+1)
 	}
 }
@@ -0,0 +1,42 @@
+package radix
+
+import "testing"
+
+// loadBenchWords loads the system dictionary for benchmarking, skipping the
+// benchmark entirely on systems where it isn't installed.
+func loadBenchWords(b *testing.B) []string {
+	words, err := (&LinuxDictionary{}).GetWords()
+	if err != nil {
+		b.Skipf("skipping: %v", err)
+	}
+	return words
+}
+
+func BenchmarkTreeInsertWord(b *testing.B) {
+	words := loadBenchWords(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rt := InitTree()
+		for _, word := range words {
+			rt.InsertWord(word)
+		}
+	}
+}
+
+func BenchmarkTreeSearch(b *testing.B) {
+	words := loadBenchWords(b)
+
+	rt := InitTree()
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			rt.Search(word)
+		}
+	}
+}
@@ -9,21 +9,21 @@ func TestTreeInsertAtRoot(t *testing.T) {
 	rt.InsertWord("test")
 	rt.InsertWord("slow")
 
-	if len(rt.Root.Children) != 2 {
-		t.Errorf("Tree should have 3 children, found %d", len(rt.Root.Children))
+	if len(rt.Root.Children()) != 2 {
+		t.Errorf("Tree should have 2 children, found %d", len(rt.Root.Children()))
 	}
 
 	var currentLabel string
 
-	currentLabel = rt.Root.Children[0].Label
-	if currentLabel != "test" {
-		t.Errorf("Child should have label 'test' found '%s'", currentLabel)
-	}
-
-	currentLabel = rt.Root.Children[1].Label
+	currentLabel = rt.Root.Children()[0].Label
 	if currentLabel != "slow" {
 		t.Errorf("Child should have label 'slow' found '%s'", currentLabel)
 	}
+
+	currentLabel = rt.Root.Children()[1].Label
+	if currentLabel != "test" {
+		t.Errorf("Child should have label 'test' found '%s'", currentLabel)
+	}
 }
 
 func TestTreeInsertExtendedWord(t *testing.T) {
@@ -32,31 +32,31 @@ func TestTreeInsertExtendedWord(t *testing.T) {
 	rt.InsertWord("slow")
 	rt.InsertWord("slower")
 
-	if len(rt.Root.Children) != 2 {
-		t.Errorf("Tree should have 2 children, found %d", len(rt.Root.Children))
+	if len(rt.Root.Children()) != 2 {
+		t.Errorf("Tree should have 2 children, found %d", len(rt.Root.Children()))
 	}
 
-	if len(rt.Root.Children[0].Children) != 0 {
-		t.Errorf("Child should have no children, found %d", len(rt.Root.Children[0].Children))
+	if len(rt.Root.Children()[0].Children()) != 1 {
+		t.Errorf("Child should have 1 child, found %d", len(rt.Root.Children()[0].Children()))
 	}
 
-	if len(rt.Root.Children[1].Children) != 1 {
-		t.Errorf("Child should have 1 child, found %d", len(rt.Root.Children[1].Children))
+	if len(rt.Root.Children()[1].Children()) != 0 {
+		t.Errorf("Child should have no children, found %d", len(rt.Root.Children()[1].Children()))
 	}
 
 	var currentLabel string
 
-	currentLabel = rt.Root.Children[0].Label
-	if currentLabel != "test" {
-		t.Errorf("Child should have label 'test', found %s", currentLabel)
-	}
-
-	currentLabel = rt.Root.Children[1].Label
+	currentLabel = rt.Root.Children()[0].Label
 	if currentLabel != "slow" {
 		t.Errorf("Child should have label 'slow', found %s", currentLabel)
 	}
 
-	currentLabel = rt.Root.Children[1].Children[0].Label
+	currentLabel = rt.Root.Children()[1].Label
+	if currentLabel != "test" {
+		t.Errorf("Child should have label 'test', found %s", currentLabel)
+	}
+
+	currentLabel = rt.Root.Children()[0].Children()[0].Label
 	if currentLabel != "er" {
 		t.Errorf("Child should have label 'er', found %s", currentLabel)
 	}
@@ -67,22 +67,22 @@ func TestTreeInsertPrefix(t *testing.T) {
 	rt.InsertWord("tester")
 	rt.InsertWord("test")
 
-	if len(rt.Root.Children) != 1 {
-		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children))
+	if len(rt.Root.Children()) != 1 {
+		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children()))
 	}
 
-	if len(rt.Root.Children[0].Children) != 1 {
-		t.Errorf("Child should have 1 child, found %d", len(rt.Root.Children[0].Children))
+	if len(rt.Root.Children()[0].Children()) != 1 {
+		t.Errorf("Child should have 1 child, found %d", len(rt.Root.Children()[0].Children()))
 	}
 
 	var currentLabel string
 
-	currentLabel = rt.Root.Children[0].Label
+	currentLabel = rt.Root.Children()[0].Label
 	if currentLabel != "test" {
 		t.Errorf("Child should have label 'test', found '%s'", currentLabel)
 	}
 
-	currentLabel = rt.Root.Children[0].Children[0].Label
+	currentLabel = rt.Root.Children()[0].Children()[0].Label
 	if currentLabel != "er" {
 		t.Errorf("Child should have label 'er', found '%s'", currentLabel)
 	}
@@ -93,30 +93,30 @@ func TestTreeInsertSplitNode(t *testing.T) {
 	rt.InsertWord("test")
 	rt.InsertWord("team")
 
-	if len(rt.Root.Children) != 1 {
-		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children))
+	if len(rt.Root.Children()) != 1 {
+		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children()))
 	}
 
-	if len(rt.Root.Children[0].Children) != 2 {
-		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children[0].Children))
+	if len(rt.Root.Children()[0].Children()) != 2 {
+		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children()[0].Children()))
 	}
 
 	var currentLabel string
 
-	currentLabel = rt.Root.Children[0].Label
+	currentLabel = rt.Root.Children()[0].Label
 	if currentLabel != "te" {
 		t.Errorf("Child should have label 'te', found '%s'", currentLabel)
 	}
 
-	currentLabel = rt.Root.Children[0].Children[0].Label
-	if currentLabel != "st" {
-		t.Errorf("Child should have label 'st', found %s", currentLabel)
-	}
-
-	currentLabel = rt.Root.Children[0].Children[1].Label
+	currentLabel = rt.Root.Children()[0].Children()[0].Label
 	if currentLabel != "am" {
 		t.Errorf("Child should have label 'am', found %s", currentLabel)
 	}
+
+	currentLabel = rt.Root.Children()[0].Children()[1].Label
+	if currentLabel != "st" {
+		t.Errorf("Child should have label 'st', found %s", currentLabel)
+	}
 }
 
 func TestTreeInsertSplitPatchNode(t *testing.T) {
@@ -125,48 +125,48 @@ func TestTreeInsertSplitPatchNode(t *testing.T) {
 	rt.InsertWord("team")
 	rt.InsertWord("toast")
 
-	if len(rt.Root.Children) != 1 {
-		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children))
+	if len(rt.Root.Children()) != 1 {
+		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children()))
 	}
 
-	if len(rt.Root.Children[0].Children) != 2 {
-		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children[0].Children))
+	if len(rt.Root.Children()[0].Children()) != 2 {
+		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children()[0].Children()))
 	}
 
-	if len(rt.Root.Children[0].Children[0].Children) != 2 {
-		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children[0].Children[0].Children))
+	if len(rt.Root.Children()[0].Children()[0].Children()) != 2 {
+		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children()[0].Children()[0].Children()))
 	}
 
-	if len(rt.Root.Children[0].Children[1].Children) != 0 {
-		t.Errorf("Child should have 0 children, found %d", len(rt.Root.Children[0].Children[1].Children))
+	if len(rt.Root.Children()[0].Children()[1].Children()) != 0 {
+		t.Errorf("Child should have 0 children, found %d", len(rt.Root.Children()[0].Children()[1].Children()))
 	}
 
 	var currentLabel string
 
-	currentLabel = rt.Root.Children[0].Label
+	currentLabel = rt.Root.Children()[0].Label
 	if currentLabel != "t" {
 		t.Errorf("Child should have label 't', found %s", currentLabel)
 	}
 
-	currentLabel = rt.Root.Children[0].Children[0].Label
+	currentLabel = rt.Root.Children()[0].Children()[0].Label
 	if currentLabel != "e" {
 		t.Errorf("Child should have label 'e', found %s", currentLabel)
 	}
 
-	currentLabel = rt.Root.Children[0].Children[1].Label
+	currentLabel = rt.Root.Children()[0].Children()[1].Label
 	if currentLabel != "oast" {
 		t.Errorf("Child should have label 'oast', found %s", currentLabel)
 	}
 
-	currentLabel = rt.Root.Children[0].Children[0].Children[0].Label
-	if currentLabel != "st" {
-		t.Errorf("Child should have label 'st', found %s", currentLabel)
-	}
-
-	currentLabel = rt.Root.Children[0].Children[0].Children[1].Label
+	currentLabel = rt.Root.Children()[0].Children()[0].Children()[0].Label
 	if currentLabel != "am" {
 		t.Errorf("Child should have label 'am', found %s", currentLabel)
 	}
+
+	currentLabel = rt.Root.Children()[0].Children()[0].Children()[1].Label
+	if currentLabel != "st" {
+		t.Errorf("Child should have label 'st', found %s", currentLabel)
+	}
 }
 
 func TestSearchTree(t *testing.T) {
@@ -252,3 +252,161 @@ func TestGetSuggestionsForPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestGetSuggestionsMidLabelOnBranchingNode(t *testing.T) {
+	words := []string{"abcdef", "abcxyz", "abcdefghij", "abcdeZZZ"}
+
+	rt := InitTree()
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	suggestions := rt.GetSuggestions("abcd")
+
+	expected := []string{"abcdeZZZ", "abcdef", "abcdefghij"}
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, found %d: %v", len(expected), len(suggestions), suggestions)
+	}
+	for i, word := range expected {
+		if suggestions[i] != word {
+			t.Errorf("Expected suggestion '%s' at position %d, found '%s'", word, i, suggestions[i])
+		}
+	}
+}
+
+func TestTreeInsertAndGetValue(t *testing.T) {
+	rt := InitTree()
+
+	if _, updated := rt.Insert("test", 1); updated {
+		t.Errorf("Insert should report updated=false for a brand new key")
+	}
+
+	value, ok := rt.Get("test")
+	if !ok {
+		t.Errorf("Get should find 'test' after it has been inserted")
+	}
+	if value != 1 {
+		t.Errorf("Expected value 1 for 'test', found %v", value)
+	}
+
+	old, updated := rt.Insert("test", 2)
+	if !updated {
+		t.Errorf("Insert should report updated=true when overwriting an existing key")
+	}
+	if old != 1 {
+		t.Errorf("Expected old value 1 when overwriting 'test', found %v", old)
+	}
+
+	value, _ = rt.Get("test")
+	if value != 2 {
+		t.Errorf("Expected value 2 for 'test' after overwrite, found %v", value)
+	}
+
+	if _, ok := rt.Get("tes"); ok {
+		t.Errorf("Get should not find 'tes' since it was never inserted")
+	}
+}
+
+func TestTreeDeleteLeaf(t *testing.T) {
+	rt := InitTree()
+	rt.Insert("test", 1)
+	rt.Insert("tester", 2)
+
+	value, ok := rt.Delete("tester")
+	if !ok {
+		t.Errorf("Delete should report ok=true for an existing key")
+	}
+	if value != 2 {
+		t.Errorf("Expected deleted value 2 for 'tester', found %v", value)
+	}
+
+	if rt.Search("tester") {
+		t.Errorf("'tester' should no longer be found after delete")
+	}
+	if !rt.Search("test") {
+		t.Errorf("'test' should still be found after deleting 'tester'")
+	}
+
+	if len(rt.Root.Children()[0].Children()) != 0 {
+		t.Errorf("'test' node should have no children once its only child leaf is deleted, found %d", len(rt.Root.Children()[0].Children()))
+	}
+}
+
+func TestTreeDeleteWithMerge(t *testing.T) {
+	rt := InitTree()
+	rt.Insert("test", 1)
+	rt.Insert("te", 2)
+
+	if _, ok := rt.Delete("te"); !ok {
+		t.Errorf("Delete should report ok=true for an existing key")
+	}
+
+	if rt.Search("te") {
+		t.Errorf("'te' should no longer be found after delete")
+	}
+	if !rt.Search("test") {
+		t.Errorf("'test' should still be found after deleting 'te'")
+	}
+
+	if len(rt.Root.Children()) != 1 {
+		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children()))
+	}
+
+	currentLabel := rt.Root.Children()[0].Label
+	if currentLabel != "test" {
+		t.Errorf("'te' and its sole child should merge back into 'test', found '%s'", currentLabel)
+	}
+}
+
+func TestTreeDeleteCascades(t *testing.T) {
+	rt := InitTree()
+	rt.InsertWord("test")
+	rt.InsertWord("team")
+	rt.InsertWord("toast")
+
+	if _, ok := rt.Delete("team"); !ok {
+		t.Errorf("Delete should report ok=true for an existing key")
+	}
+
+	if rt.Search("team") {
+		t.Errorf("'team' should no longer be found after delete")
+	}
+	if !rt.Search("test") || !rt.Search("toast") {
+		t.Errorf("'test' and 'toast' should still be found after deleting 'team'")
+	}
+
+	if len(rt.Root.Children()) != 1 {
+		t.Errorf("Tree should have 1 child, found %d", len(rt.Root.Children()))
+	}
+
+	currentLabel := rt.Root.Children()[0].Label
+	if currentLabel != "t" {
+		t.Errorf("Child should have label 't', found '%s'", currentLabel)
+	}
+
+	if len(rt.Root.Children()[0].Children()) != 2 {
+		t.Errorf("Child should have 2 children, found %d", len(rt.Root.Children()[0].Children()))
+	}
+
+	currentLabel = rt.Root.Children()[0].Children()[0].Label
+	if currentLabel != "est" {
+		t.Errorf("'e' and its sole remaining child 'st' should merge into 'est', found '%s'", currentLabel)
+	}
+
+	currentLabel = rt.Root.Children()[0].Children()[1].Label
+	if currentLabel != "oast" {
+		t.Errorf("Child should have label 'oast', found '%s'", currentLabel)
+	}
+}
+
+func TestTreeDeleteMissingKey(t *testing.T) {
+	rt := InitTree()
+	rt.InsertWord("test")
+
+	if _, ok := rt.Delete("testing"); ok {
+		t.Errorf("Delete should report ok=false for a key that was never inserted")
+	}
+	if !rt.Search("test") {
+		t.Errorf("'test' should be unaffected by deleting a missing key")
+	}
+}
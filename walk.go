@@ -0,0 +1,129 @@
+package radix
+
+import (
+	"errors"
+	"strings"
+)
+
+// SkipSubtree can be returned by a Walk callback to stop the walker from
+// descending into the current node's children while it continues on with
+// the node's siblings. For WalkPath, where there are no siblings to speak
+// of, it has the same effect as StopWalk.
+var SkipSubtree = errors.New("radix: skip subtree")
+
+// StopWalk can be returned by a Walk callback to terminate the walk
+// immediately. It is never surfaced to the caller: Walk, WalkPrefix, and
+// WalkPath all translate it into a nil error.
+var StopWalk = errors.New("radix: stop walk")
+
+// Walk visits every key/value pair stored in the tree in lexicographic
+// order, calling fn for each one. Returning SkipSubtree from fn skips the
+// current node's children; returning StopWalk ends the walk early. Any
+// other non-nil error aborts the walk and is returned verbatim.
+//
+// On a concurrent tree, Walk holds the read lock for the duration of the
+// walk unless the tree was created with AllowReentrantRead(true): fn must
+// not call back into the tree, or it will deadlock against its own lock.
+func (t *Tree) Walk(fn func(key string, value interface{}) error) error {
+	if !t.allowReentrantRead {
+		t.rlock()
+		defer t.runlock()
+	}
+
+	err := t.walk(t.Root, "", fn)
+	if err == StopWalk {
+		return nil
+	}
+	return err
+}
+
+// WalkPrefix visits every key/value pair whose key has the given prefix, in
+// lexicographic order. It follows the same callback control rules as Walk,
+// including the locking caveat.
+func (t *Tree) WalkPrefix(prefix string, fn func(key string, value interface{}) error) error {
+	if !t.allowReentrantRead {
+		t.rlock()
+		defer t.runlock()
+	}
+
+	matchedNode, matchedIdx, restQuery, nodePrefix := t.findMatchedNodeMeta(prefix, "", t.Root)
+
+	// No node matches prefix at all.
+	if matchedNode == nil || (matchedIdx < len(restQuery) && len(restQuery) > 0) {
+		return nil
+	}
+
+	// We matched partway into a label; recompute the prefix so the walk
+	// below reports full keys rather than ones missing their common stem.
+	// Node.Prefix is not the accumulated path from the root for every node
+	// (a split or a merge sets it to the node's own label), so it can't be
+	// substituted in here — prefix+matchedNode.Label is the full path in
+	// both the leaf and non-leaf case.
+	if matchedIdx > 0 && matchedIdx < len(matchedNode.Label) && matchedIdx == len(restQuery) && len(restQuery) > 0 {
+		nodePrefix += matchedNode.Label
+	}
+
+	err := t.walk(matchedNode, nodePrefix, fn)
+	if err == StopWalk {
+		return nil
+	}
+	return err
+}
+
+// WalkPath visits every word-boundary node along the path from the root to
+// key, in root-to-leaf order. This is useful for longest-prefix routing,
+// where every matching boundary along the way is a candidate. It follows
+// the same callback control rules as Walk, including the locking caveat.
+func (t *Tree) WalkPath(key string, fn func(key string, value interface{}) error) error {
+	if !t.allowReentrantRead {
+		t.rlock()
+		defer t.runlock()
+	}
+
+	err := t.walkPath(t.Root, key, "", fn)
+	if err == StopWalk {
+		return nil
+	}
+	return err
+}
+
+func (t *Tree) walk(node *Node, key string, fn func(key string, value interface{}) error) error {
+	if node.IsWord() {
+		if err := fn(key, node.Value); err != nil {
+			if err == SkipSubtree {
+				return nil
+			}
+			return err
+		}
+	}
+
+	for _, child := range node.children.sorted() {
+		if err := t.walk(child, key+child.Label, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Tree) walkPath(node *Node, query, accumulated string, fn func(key string, value interface{}) error) error {
+	if node.IsWord() {
+		if err := fn(accumulated, node.Value); err != nil {
+			if err == SkipSubtree {
+				return StopWalk
+			}
+			return err
+		}
+	}
+
+	if query == "" {
+		return nil
+	}
+
+	child := node.children.find(query[0])
+	if child == nil || !strings.HasPrefix(query, child.Label) {
+		return nil
+	}
+
+	return t.walkPath(child, query[len(child.Label):], accumulated+child.Label, fn)
+}
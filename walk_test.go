@@ -0,0 +1,204 @@
+package radix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTreeWalkLexicographicOrder(t *testing.T) {
+	rt := InitTree()
+	words := []string{"test", "team", "toast", "slow"}
+
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	var visited []string
+
+	if err := rt.Walk(func(key string, value interface{}) error {
+		visited = append(visited, key)
+		return nil
+	}); err != nil {
+		t.Errorf("Walk returned an unexpected error: %v", err)
+	}
+
+	expected := []string{"slow", "team", "test", "toast"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %d visited keys, found %d", len(expected), len(visited))
+	}
+	for i, key := range expected {
+		if visited[i] != key {
+			t.Errorf("Expected key '%s' at position %d, found '%s'", key, i, visited[i])
+		}
+	}
+}
+
+func TestTreeWalkStopWalk(t *testing.T) {
+	rt := InitTree()
+	rt.InsertWord("slow")
+	rt.InsertWord("team")
+	rt.InsertWord("test")
+
+	var visited []string
+
+	err := rt.Walk(func(key string, value interface{}) error {
+		visited = append(visited, key)
+		if key == "team" {
+			return StopWalk
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Walk should swallow StopWalk and return nil, found %v", err)
+	}
+	if len(visited) != 2 {
+		t.Errorf("Expected Walk to stop after 2 keys, visited %d", len(visited))
+	}
+}
+
+func TestTreeWalkSkipSubtree(t *testing.T) {
+	rt := InitTree()
+	rt.InsertWord("team")
+	rt.InsertWord("teamed")
+	rt.InsertWord("test")
+
+	var visited []string
+
+	err := rt.Walk(func(key string, value interface{}) error {
+		visited = append(visited, key)
+		if key == "team" {
+			return SkipSubtree
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Walk returned an unexpected error: %v", err)
+	}
+
+	expected := []string{"team", "test"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %d visited keys, found %d: %v", len(expected), len(visited), visited)
+	}
+	for i, key := range expected {
+		if visited[i] != key {
+			t.Errorf("Expected key '%s' at position %d, found '%s'", key, i, visited[i])
+		}
+	}
+}
+
+func TestTreeWalkPropagatesError(t *testing.T) {
+	rt := InitTree()
+	rt.InsertWord("test")
+
+	boom := errors.New("boom")
+
+	err := rt.Walk(func(key string, value interface{}) error {
+		return boom
+	})
+
+	if err != boom {
+		t.Errorf("Expected Walk to return the callback's error verbatim, found %v", err)
+	}
+}
+
+func TestTreeWalkPrefix(t *testing.T) {
+	words := []string{"slow", "slower", "team", "teamed", "teamedup", "test", "tester", "toast", "water"}
+
+	rt := InitTree()
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	var visited []string
+
+	if err := rt.WalkPrefix("te", func(key string, value interface{}) error {
+		visited = append(visited, key)
+		return nil
+	}); err != nil {
+		t.Errorf("WalkPrefix returned an unexpected error: %v", err)
+	}
+
+	expected := []string{"team", "teamed", "teamedup", "test", "tester"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %d visited keys, found %d: %v", len(expected), len(visited), visited)
+	}
+	for i, key := range expected {
+		if visited[i] != key {
+			t.Errorf("Expected key '%s' at position %d, found '%s'", key, i, visited[i])
+		}
+	}
+}
+
+func TestTreeWalkPrefixMidLabelOnBranchingNode(t *testing.T) {
+	words := []string{"abcdef", "abcxyz", "abcdefghij", "abcdeZZZ"}
+
+	rt := InitTree()
+	for _, word := range words {
+		rt.InsertWord(word)
+	}
+
+	var visited []string
+
+	if err := rt.WalkPrefix("abcd", func(key string, value interface{}) error {
+		visited = append(visited, key)
+		return nil
+	}); err != nil {
+		t.Errorf("WalkPrefix returned an unexpected error: %v", err)
+	}
+
+	expected := []string{"abcdeZZZ", "abcdef", "abcdefghij"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %d visited keys, found %d: %v", len(expected), len(visited), visited)
+	}
+	for i, key := range expected {
+		if visited[i] != key {
+			t.Errorf("Expected key '%s' at position %d, found '%s'", key, i, visited[i])
+		}
+	}
+}
+
+func TestTreeWalkPrefixNoMatch(t *testing.T) {
+	rt := InitTree()
+	rt.InsertWord("test")
+
+	visited := 0
+
+	if err := rt.WalkPrefix("wafer", func(key string, value interface{}) error {
+		visited++
+		return nil
+	}); err != nil {
+		t.Errorf("WalkPrefix returned an unexpected error: %v", err)
+	}
+
+	if visited != 0 {
+		t.Errorf("Expected no keys visited for an unmatched prefix, found %d", visited)
+	}
+}
+
+func TestTreeWalkPath(t *testing.T) {
+	rt := InitTree()
+	rt.Insert("te", "te-value")
+	rt.Insert("test", "test-value")
+	rt.Insert("tester", "tester-value")
+
+	var visited []string
+
+	if err := rt.WalkPath("testers", func(key string, value interface{}) error {
+		visited = append(visited, key)
+		return nil
+	}); err != nil {
+		t.Errorf("WalkPath returned an unexpected error: %v", err)
+	}
+
+	expected := []string{"te", "test", "tester"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %d visited keys, found %d: %v", len(expected), len(visited), visited)
+	}
+	for i, key := range expected {
+		if visited[i] != key {
+			t.Errorf("Expected key '%s' at position %d, found '%s'", key, i, visited[i])
+		}
+	}
+}